@@ -0,0 +1,74 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package volume contains the types used to record, in the backup's volume
+// info file, how each volume was backed up and what is needed to restore it.
+package volume
+
+import (
+	v1 "k8s.io/api/core/v1"
+)
+
+// VolumeBackupMethod represents the mechanism used to back up a single volume.
+type VolumeBackupMethod string
+
+const (
+	NativeSnapshot  VolumeBackupMethod = "NativeSnapshot"
+	PodVolumeBackup VolumeBackupMethod = "PodVolumeBackup"
+	CSISnapshot     VolumeBackupMethod = "CSISnapshot"
+)
+
+// VolumeInfo records the information needed to restore a single volume that
+// was backed up, keyed to the PVC/PV it came from.
+type VolumeInfo struct {
+	// BackupMethod indicates how the volume's data was backed up.
+	BackupMethod VolumeBackupMethod
+
+	// PVCName is the name of the PVC the volume was bound to at backup time.
+	PVCName string
+
+	// PVCNamespace is the namespace of the PVC the volume was bound to at backup time.
+	PVCNamespace string
+
+	// PVName is the name of the PV the volume was bound to at backup time.
+	PVName string
+
+	// PVInfo holds the subset of the PV spec that needs to be re-applied after
+	// a dynamically-provisioned PV replaces the original one on restore.
+	PVInfo *PVInfo
+}
+
+// PVInfo records the fields of a PersistentVolume that are not recreated
+// automatically by dynamic provisioning and therefore need to be restored
+// explicitly from the backup.
+type PVInfo struct {
+	// ReclaimPolicy is the value of the PV's Spec.PersistentVolumeReclaimPolicy at backup time.
+	ReclaimPolicy string
+
+	// Labels is the set of labels applied to the PV at backup time.
+	Labels map[string]string
+
+	// Annotations is the set of annotations applied to the PV at backup time. Annotations
+	// owned by the dynamic provisioner (e.g. pv.kubernetes.io/provisioned-by) are filtered
+	// out before being re-applied; see pvAnnotationDenyList in the restore finalizer controller.
+	Annotations map[string]string
+
+	// MountOptions is the PV's Spec.MountOptions at backup time.
+	MountOptions []string
+
+	// NodeAffinity is the PV's Spec.NodeAffinity at backup time.
+	NodeAffinity *v1.VolumeNodeAffinity
+}
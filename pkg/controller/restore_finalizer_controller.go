@@ -19,14 +19,15 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	"k8s.io/apimachinery/pkg/util/wait"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,26 +40,118 @@ import (
 	"github.com/vmware-tanzu/velero/pkg/metrics"
 	"github.com/vmware-tanzu/velero/pkg/persistence"
 	"github.com/vmware-tanzu/velero/pkg/plugin/clientmgmt"
-	"github.com/vmware-tanzu/velero/pkg/restore"
+	"github.com/vmware-tanzu/velero/pkg/plugin/velero"
+	restorepkg "github.com/vmware-tanzu/velero/pkg/restore"
 	kubeutil "github.com/vmware-tanzu/velero/pkg/util/kube"
 	"github.com/vmware-tanzu/velero/pkg/util/results"
 )
 
 const (
+	// DefaultRestoreFinalizerConcurrency is the default number of PVs that are patched
+	// concurrently when no value is supplied to NewRestoreFinalizerReconciler, i.e. when the
+	// caller didn't configure --restore-finalizer-concurrency.
+	DefaultRestoreFinalizerConcurrency = 3
+
+	// PVPatchMaximumDuration is the default amount of time to wait, across all retries, for a
+	// dynamically provisioned PVC/PV pair to become bound before giving up on patching it, used
+	// when no value is supplied to NewRestoreFinalizerReconciler.
 	PVPatchMaximumDuration = 10 * time.Minute
+
+	// DefaultMaxFinalizerRetries bounds how many times the reconciler will requeue while waiting
+	// for dynamically-provisioned PVs to bind, before giving up on the remaining ones and
+	// finishing the restore as PartiallyFailed.
+	DefaultMaxFinalizerRetries = 30
+
+	finalizerRetryBaseBackoff = 10 * time.Second
+	finalizerRetryMaxBackoff  = 5 * time.Minute
+
+	// finalizerProgressFileFmt is the name, within the backup store, of the checkpoint file that
+	// tracks which PVCs have already been patched by a given restore's finalization.
+	finalizerProgressFileFmt = "restore-%s-finalizer-progress.json"
 )
 
+// finalizerBackoff returns how long the finalizer reconciler should wait before re-checking
+// unbound PVCs, growing exponentially with the number of attempts already made and capped at
+// finalizerRetryMaxBackoff so a slow CSI provisioner doesn't push requeues out indefinitely.
+func finalizerBackoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	// cap the shift to avoid overflow for pathologically high attempt counts
+	if attempt > 10 {
+		attempt = 10
+	}
+	backoff := finalizerRetryBaseBackoff * time.Duration(1<<uint(attempt-1))
+	if backoff > finalizerRetryMaxBackoff {
+		backoff = finalizerRetryMaxBackoff
+	}
+	return backoff
+}
+
+// pvAnnotationDenyList is the set of annotation keys that must never be
+// copied from backed-up PV info onto a newly dynamically-provisioned PV, because
+// they are owned and managed by the CSI provisioner/controller and restoring stale
+// values would confuse future volume operations (deletion, expansion, etc.).
+// The list is fixed; no flag or option adds keys to it or removes keys from it.
+var pvAnnotationDenyList = map[string]bool{
+	"pv.kubernetes.io/provisioned-by":                            true,
+	"pv.kubernetes.io/bound-by-controller":                       true,
+	"volume.kubernetes.io/provisioner-deletion-secret-name":      true,
+	"volume.kubernetes.io/provisioner-deletion-secret-namespace": true,
+	"volume.beta.kubernetes.io/storage-provisioner":              true,
+	"volume.kubernetes.io/storage-provisioner":                   true,
+}
+
+// filterDeniedAnnotations returns a copy of annotations with any key in
+// pvAnnotationDenyList removed.
+func filterDeniedAnnotations(annotations map[string]string) map[string]string {
+	filtered := make(map[string]string, len(annotations))
+	for k, v := range annotations {
+		if pvAnnotationDenyList[k] {
+			continue
+		}
+		filtered[k] = v
+	}
+	return filtered
+}
+
+// restoreFinalizerBackupStore is the subset of persistence.BackupStore used by the restore
+// finalizer reconciler's finalization logic. Any persistence.BackupStore implementation
+// satisfies it automatically; scoping it down to just these methods keeps reconcileFinalization
+// testable against a small fake instead of requiring a fake that implements every
+// persistence.BackupStore method.
+type restoreFinalizerBackupStore interface {
+	GetBackupVolumeInfos(backupName string) ([]*internalVolume.VolumeInfo, error)
+	GetRestoredResourceList(restoreName string) (map[string][]string, error)
+	GetRestoreFinalizerProgress(restoreName string) (restorepkg.FinalizerProgress, error)
+	PutRestoreFinalizerProgress(restoreName string, progress restorepkg.FinalizerProgress) error
+	GetRestoreResults(restoreName string) (map[string]results.Result, error)
+	PutRestoreResults(restoreName string, restoreResults map[string]results.Result) error
+}
+
+// restoreFinalizerPluginManager is the subset of clientmgmt.Manager used by the restore
+// finalizer reconciler's finalization logic.
+type restoreFinalizerPluginManager interface {
+	GetRestoreFinalizerActions() ([]velero.RestoreFinalizerAction, error)
+}
+
 type restoreFinalizerReconciler struct {
 	client.Client
-	namespace         string
-	logger            logrus.FieldLogger
-	newPluginManager  func(logger logrus.FieldLogger) clientmgmt.Manager
-	backupStoreGetter persistence.ObjectBackupStoreGetter
-	metrics           *metrics.ServerMetrics
-	clock             clock.WithTickerAndDelayedExecution
-	crClient          client.Client
+	namespace                   string
+	logger                      logrus.FieldLogger
+	newPluginManager            func(logger logrus.FieldLogger) clientmgmt.Manager
+	backupStoreGetter           persistence.ObjectBackupStoreGetter
+	metrics                     *metrics.ServerMetrics
+	clock                       clock.WithTickerAndDelayedExecution
+	crClient                    client.Client
+	restoreFinalizerConcurrency int
+	pvPatchTimeout              time.Duration
 }
 
+// NewRestoreFinalizerReconciler creates a restoreFinalizerReconciler. restoreFinalizerConcurrency
+// and pvPatchTimeout correspond to the --restore-finalizer-concurrency and --pv-patch-timeout
+// flags registered by RestoreFinalizerReconcilerConfig.BindFlags; a value <= 0 falls back to
+// DefaultRestoreFinalizerConcurrency/PVPatchMaximumDuration.
 func NewRestoreFinalizerReconciler(
 	logger logrus.FieldLogger,
 	namespace string,
@@ -67,16 +160,20 @@ func NewRestoreFinalizerReconciler(
 	backupStoreGetter persistence.ObjectBackupStoreGetter,
 	metrics *metrics.ServerMetrics,
 	crClient client.Client,
+	restoreFinalizerConcurrency int,
+	pvPatchTimeout time.Duration,
 ) *restoreFinalizerReconciler {
 	return &restoreFinalizerReconciler{
-		Client:            client,
-		logger:            logger,
-		namespace:         namespace,
-		newPluginManager:  newPluginManager,
-		backupStoreGetter: backupStoreGetter,
-		metrics:           metrics,
-		clock:             &clock.RealClock{},
-		crClient:          crClient,
+		Client:                      client,
+		logger:                      logger,
+		namespace:                   namespace,
+		newPluginManager:            newPluginManager,
+		backupStoreGetter:           backupStoreGetter,
+		metrics:                     metrics,
+		clock:                       &clock.RealClock{},
+		crClient:                    crClient,
+		restoreFinalizerConcurrency: restoreFinalizerConcurrency,
+		pvPatchTimeout:              pvPatchTimeout,
 	}
 }
 
@@ -138,6 +235,21 @@ func (r *restoreFinalizerReconciler) Reconcile(ctx context.Context, req ctrl.Req
 		return ctrl.Result{}, errors.Wrap(err, "error getting backup store")
 	}
 
+	return r.reconcileFinalization(log, restore, original, backupStore, pluginManager)
+}
+
+// reconcileFinalization drives a restore through the built-in finalization tasks (dynamic PV
+// patching, then any registered RestoreFinalizerAction plugins), given a restore that is already
+// known to be awaiting finalization and a backup store/plugin manager already resolved for it. It
+// is split out from Reconcile so the checkpoint/retry/backoff state machine can be exercised
+// directly in tests without needing a full backup+storage-location fixture.
+func (r *restoreFinalizerReconciler) reconcileFinalization(
+	log logrus.FieldLogger,
+	restore *velerov1api.Restore,
+	original *velerov1api.Restore,
+	backupStore restoreFinalizerBackupStore,
+	pluginManager restoreFinalizerPluginManager,
+) (ctrl.Result, error) {
 	volumeInfo, err := backupStore.GetBackupVolumeInfos(restore.Spec.BackupName)
 	if err != nil {
 		log.WithError(err).Errorf("error getting volumeInfo for backup %s", restore.Spec.BackupName)
@@ -152,14 +264,66 @@ func (r *restoreFinalizerReconciler) Reconcile(ctx context.Context, req ctrl.Req
 
 	restoredPVCList := getRestoredPVCFromRestoredResourceList(restoredResourceList)
 
+	progress, err := getFinalizerProgress(backupStore, restore.Name)
+	if err != nil {
+		// A genuine read failure (as opposed to "no checkpoint exists yet") must not be treated
+		// as a fresh start: doing so would reset Attempts/FirstAttempt on every reconcile and the
+		// retry budget below could never be reached, so a restore that should eventually give up
+		// would instead retry forever.
+		log.WithError(err).Error("error reading restore finalizer checkpoint, will retry")
+		return ctrl.Result{}, err
+	}
+	if progress.PatchedPVCs == nil {
+		progress.PatchedPVCs = make(map[string]bool)
+	}
+	if progress.FirstAttempt.IsZero() {
+		progress.FirstAttempt = r.clock.Now()
+	}
+	progress.Attempts++
+
 	finalizerCtx := &finalizerContext{
 		logger:          log,
 		restore:         restore,
 		crClient:        r.crClient,
 		volumeInfo:      volumeInfo,
 		restoredPVCList: restoredPVCList,
+		pluginManager:   pluginManager,
+		concurrency:     r.restoreFinalizerConcurrency,
+		metrics:         r.metrics,
 	}
-	warnings, errs := finalizerCtx.execute()
+
+	pending, newlyPatched, pdpErrs := finalizerCtx.patchDynamicPVWithVolumeInfo(progress.PatchedPVCs)
+	for pvcKey := range newlyPatched {
+		progress.PatchedPVCs[pvcKey] = true
+	}
+
+	pvPatchTimeout := r.pvPatchTimeout
+	if pvPatchTimeout <= 0 {
+		pvPatchTimeout = PVPatchMaximumDuration
+	}
+	retryBudgetExceeded := progress.Attempts >= DefaultMaxFinalizerRetries || r.clock.Now().Sub(progress.FirstAttempt) >= pvPatchTimeout
+
+	if len(pending) > 0 && !retryBudgetExceeded {
+		if err := putFinalizerProgress(backupStore, restore.Name, progress); err != nil {
+			log.WithError(err).Error("error checkpointing restore finalizer progress")
+		}
+		backoff := finalizerBackoff(progress.Attempts)
+		log.Infof("%d PVC(s) not yet bound, re-queuing finalization in %s", len(pending), backoff)
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
+	for pvcKey := range pending {
+		pdpErrs.Add(restoredNamespaceFromPVCKey(pvcKey), fmt.Errorf("giving up waiting for PVC %s to bind after %d attempts", pvcKey, progress.Attempts))
+	}
+
+	pluginWarnings, pluginErrs := finalizerCtx.execute()
+	// progress.FirstAttempt was set on the very first reconcile for this restore, so this spans
+	// every reconcile finalization took across the requeue/backoff loop, not just this pass.
+	r.metrics.ObserveRestoreFinalizationDuration(restore.Spec.ScheduleName, r.clock.Now().Sub(progress.FirstAttempt).Seconds())
+
+	warnings := pluginWarnings
+	errs := pdpErrs
+	errs.Merge(&pluginErrs)
 
 	warningCnt := len(warnings.Velero) + len(warnings.Cluster)
 	for _, w := range warnings.Namespaces {
@@ -198,7 +362,30 @@ func (r *restoreFinalizerReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	return ctrl.Result{}, nil
 }
 
-func (r *restoreFinalizerReconciler) updateResults(backupStore persistence.BackupStore, restore *velerov1api.Restore, newWarnings *results.Result, newErrs *results.Result) error {
+// getFinalizerProgress fetches the restore finalizer checkpoint from the backup store. A restore
+// being finalized for the first time has no checkpoint yet; that case is reported via
+// restorepkg.ErrFinalizerProgressNotFound and is not an error - the caller should start from an
+// empty restorepkg.FinalizerProgress. Any other error is a genuine failure to read the checkpoint
+// and is returned as such, so the caller doesn't mistake "couldn't read the checkpoint" for
+// "there is no checkpoint" and silently reset the retry budget on every reconcile.
+func getFinalizerProgress(backupStore restoreFinalizerBackupStore, restoreName string) (*restorepkg.FinalizerProgress, error) {
+	progress, err := backupStore.GetRestoreFinalizerProgress(restoreName)
+	if err == nil {
+		return &progress, nil
+	}
+	if errors.Is(err, restorepkg.ErrFinalizerProgressNotFound) {
+		return &restorepkg.FinalizerProgress{}, nil
+	}
+	return nil, errors.Wrap(err, "error getting restore finalizer progress")
+}
+
+// putFinalizerProgress persists the restore finalizer checkpoint to the backup store so that a
+// reconciler restart can resume from the set of PVCs already patched instead of starting over.
+func putFinalizerProgress(backupStore restoreFinalizerBackupStore, restoreName string, progress *restorepkg.FinalizerProgress) error {
+	return backupStore.PutRestoreFinalizerProgress(restoreName, *progress)
+}
+
+func (r *restoreFinalizerReconciler) updateResults(backupStore restoreFinalizerBackupStore, restore *velerov1api.Restore, newWarnings *results.Result, newErrs *results.Result) error {
 	originResults, err := backupStore.GetRestoreResults(restore.Name)
 	if err != nil {
 		return errors.Wrap(err, "error getting restore results")
@@ -212,7 +399,7 @@ func (r *restoreFinalizerReconciler) updateResults(backupStore persistence.Backu
 		"warnings": warnings,
 		"errors":   errs,
 	}
-	if err := putResults(restore, m, backupStore); err != nil {
+	if err := backupStore.PutRestoreResults(restore.Name, m); err != nil {
 		return errors.Wrap(err, "error putting restore results")
 	}
 
@@ -240,26 +427,67 @@ type finalizerContext struct {
 	crClient        client.Client
 	volumeInfo      []*internalVolume.VolumeInfo
 	restoredPVCList map[string]struct{}
+	pluginManager   restoreFinalizerPluginManager
+	concurrency     int
+	metrics         *metrics.ServerMetrics
+}
+
+// execute runs the finalization tasks that happen once dynamic PV patching has either completed
+// for every restored PVC or given up on the ones that never bound.
+func (ctx *finalizerContext) execute() (results.Result, results.Result) {
+	return ctx.runFinalizerActionPlugins()
 }
 
-func (ctx *finalizerContext) execute() (results.Result, results.Result) { //nolint:unparam //temporarily ignore the lint report: result 0 is always nil (unparam)
+// runFinalizerActionPlugins invokes every registered RestoreFinalizerAction plugin, in order,
+// after the built-in finalization steps have run. This lets operators plug in workload-specific
+// fix-ups (e.g. re-annotating PVs for their CSI driver, kicking StatefulSet rollouts, rebinding
+// external DNS) without forking Velero.
+func (ctx *finalizerContext) runFinalizerActionPlugins() (results.Result, results.Result) {
 	warnings, errs := results.Result{}, results.Result{}
 
-	// implement finalization tasks
-	pdpErrs := ctx.patchDynamicPVWithVolumeInfo()
-	errs.Merge(&pdpErrs)
+	actions, err := ctx.pluginManager.GetRestoreFinalizerActions()
+	if err != nil {
+		errs.Add("", errors.Wrap(err, "error getting restore finalizer actions"))
+		return warnings, errs
+	}
+
+	for _, action := range actions {
+		ctx.logger.Infof("Executing restore finalizer action %s", action.Name())
+
+		output, err := action.Execute(&velero.RestoreFinalizerActionExecuteInput{
+			Restore:         ctx.restore,
+			VolumeInfo:      ctx.volumeInfo,
+			RestoredPVCList: ctx.restoredPVCList,
+		})
+		if err != nil {
+			errs.Add("", errors.Wrapf(err, "error executing restore finalizer action %s", action.Name()))
+			continue
+		}
+
+		for _, w := range output.Warnings {
+			warnings.Add("", errors.New(w))
+		}
+	}
 
 	return warnings, errs
 }
 
-// patchDynamicPV patches newly dynamically provisioned PV using volume info
-// in order to restore custom settings that would otherwise be lost during dynamic PV recreation.
-func (ctx *finalizerContext) patchDynamicPVWithVolumeInfo() (errs results.Result) {
+// patchDynamicPVWithVolumeInfo makes a single, non-blocking pass over every volume that hasn't
+// already been checkpointed as patched, patching any whose dynamically-provisioned PVC/PV pair
+// has become bound. PVCs that aren't bound yet are returned in pending so the caller can re-drive
+// this method on a later, backed-off reconcile instead of blocking the goroutine on a long poll.
+func (ctx *finalizerContext) patchDynamicPVWithVolumeInfo(alreadyPatched map[string]bool) (pending map[string]bool, newlyPatched map[string]bool, errs results.Result) {
 	ctx.logger.Info("patching newly dynamically provisioned PV starts")
 
+	pending = make(map[string]bool)
+	newlyPatched = make(map[string]bool)
+
 	var pvWaitGroup sync.WaitGroup
 	var resultLock sync.Mutex
-	maxConcurrency := 3
+	maxConcurrency := ctx.concurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = DefaultRestoreFinalizerConcurrency
+	}
 	semaphore := make(chan struct{}, maxConcurrency)
 
 	for _, volumeItem := range ctx.volumeInfo {
@@ -275,87 +503,134 @@ func (ctx *finalizerContext) patchDynamicPVWithVolumeInfo() (errs results.Result
 			if _, restored := ctx.restoredPVCList[pvcKey]; !restored {
 				continue
 			}
+			if alreadyPatched[pvcKey] {
+				continue
+			}
 
 			pvWaitGroup.Add(1)
-			go func(volInfo internalVolume.VolumeInfo, restoredNamespace string) {
+			go func(volInfo internalVolume.VolumeInfo, restoredNamespace, pvcKey string) {
 				defer pvWaitGroup.Done()
 
 				semaphore <- struct{}{}
+				defer func() { <-semaphore }()
 
 				log := ctx.logger.WithField("PVC", volInfo.PVCName).WithField("PVCNamespace", restoredNamespace)
 				log.Debug("patching dynamic PV is in progress")
 
-				err := wait.PollUntilContextTimeout(context.Background(), 10*time.Second, PVPatchMaximumDuration, true, func(context.Context) (bool, error) {
-					// wait for PVC to be bound
-					pvc := &v1.PersistentVolumeClaim{}
-					err := ctx.crClient.Get(context.Background(), client.ObjectKey{Name: volInfo.PVCName, Namespace: restoredNamespace}, pvc)
-					if apierrors.IsNotFound(err) {
-						log.Debug("error not finding PVC")
-						return false, nil
-					}
-					if err != nil {
-						return false, err
-					}
-
-					if pvc.Status.Phase != v1.ClaimBound || pvc.Spec.VolumeName == "" {
-						log.Debugf("PVC: %s not ready", pvc.Name)
-						return false, nil
-					}
-
-					// wait for PV to be bound
-					pvName := pvc.Spec.VolumeName
-					pv := &v1.PersistentVolume{}
-					err = ctx.crClient.Get(context.Background(), client.ObjectKey{Name: pvName}, pv)
-					if apierrors.IsNotFound(err) {
-						log.Debugf("error not finding PV: %s", pvName)
-						return false, nil
-					}
-					if err != nil {
-						return false, err
-					}
-
-					if pv.Spec.ClaimRef == nil || pv.Status.Phase != v1.VolumeBound {
-						log.Debugf("PV: %s not ready", pvName)
-						return false, nil
-					}
-
-					// validate PV
-					if pv.Spec.ClaimRef.Name != pvc.Name || pv.Spec.ClaimRef.Namespace != restoredNamespace {
-						return false, fmt.Errorf("PV was bound by unexpected PVC, unexpected PVC: %s/%s, expected PVC: %s/%s",
-							pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name, restoredNamespace, pvc.Name)
-					}
-
-					// patch PV's reclaim policy and label using the corresponding data stored in volume info
-					if needPatch(pv, volInfo.PVInfo) {
-						updatedPV := pv.DeepCopy()
-						updatedPV.Labels = volInfo.PVInfo.Labels
-						updatedPV.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimPolicy(volInfo.PVInfo.ReclaimPolicy)
-						if err := kubeutil.PatchResource(pv, updatedPV, ctx.crClient); err != nil {
-							return false, err
-						}
-						log.Infof("newly dynamically provisioned PV:%s has been patched using volume info", pvName)
-					}
-
-					return true, nil
-				})
-
-				if err != nil {
+				bound, err := ctx.tryPatchPV(volInfo, restoredNamespace)
+
+				resultLock.Lock()
+				defer resultLock.Unlock()
+
+				switch {
+				case err != nil:
 					err = fmt.Errorf("fail to patch dynamic PV, err: %s, PVC: %s, PV: %s", err, volInfo.PVCName, volInfo.PVName)
 					ctx.logger.WithError(errors.WithStack((err))).Error("err patching dynamic PV using volume info")
-					resultLock.Lock()
-					defer resultLock.Unlock()
+					ctx.metrics.RegisterPVPatchOutcome(restoredNamespace, false)
 					errs.Add(restoredNamespace, err)
+				case !bound:
+					pending[pvcKey] = true
+				default:
+					newlyPatched[pvcKey] = true
+					ctx.metrics.RegisterPVPatchOutcome(restoredNamespace, true)
 				}
-
-				<-semaphore
-			}(*volumeItem, restoredNamespace)
+			}(*volumeItem, restoredNamespace, pvcKey)
 		}
 	}
 
 	pvWaitGroup.Wait()
 	ctx.logger.Info("patching newly dynamically provisioned PV ends")
 
-	return errs
+	return pending, newlyPatched, errs
+}
+
+// tryPatchPV makes a single, non-blocking check of whether the PVC/PV pair identified by volInfo
+// has bound, and if so patches the PV's reclaim policy, labels, annotations, mount options and
+// node affinity using the corresponding data stored in volume info. It returns true once the PV
+// has bound and (if necessary) been patched; false with a nil error means the caller should retry
+// on a future reconcile.
+func (ctx *finalizerContext) tryPatchPV(volInfo internalVolume.VolumeInfo, restoredNamespace string) (bool, error) {
+	log := ctx.logger.WithField("PVC", volInfo.PVCName).WithField("PVCNamespace", restoredNamespace)
+
+	// wait for PVC to be bound
+	pvc := &v1.PersistentVolumeClaim{}
+	err := ctx.crClient.Get(context.Background(), client.ObjectKey{Name: volInfo.PVCName, Namespace: restoredNamespace}, pvc)
+	if apierrors.IsNotFound(err) {
+		log.Debug("error not finding PVC")
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if pvc.Status.Phase != v1.ClaimBound || pvc.Spec.VolumeName == "" {
+		log.Debugf("PVC: %s not ready", pvc.Name)
+		return false, nil
+	}
+
+	// wait for PV to be bound
+	pvName := pvc.Spec.VolumeName
+	pv := &v1.PersistentVolume{}
+	err = ctx.crClient.Get(context.Background(), client.ObjectKey{Name: pvName}, pv)
+	if apierrors.IsNotFound(err) {
+		log.Debugf("error not finding PV: %s", pvName)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if pv.Spec.ClaimRef == nil || pv.Status.Phase != v1.VolumeBound {
+		log.Debugf("PV: %s not ready", pvName)
+		return false, nil
+	}
+
+	// validate PV
+	if pv.Spec.ClaimRef.Name != pvc.Name || pv.Spec.ClaimRef.Namespace != restoredNamespace {
+		return false, fmt.Errorf("PV was bound by unexpected PVC, unexpected PVC: %s/%s, expected PVC: %s/%s",
+			pv.Spec.ClaimRef.Namespace, pv.Spec.ClaimRef.Name, restoredNamespace, pvc.Name)
+	}
+
+	if needPatch(pv, volInfo.PVInfo) {
+		updatedPV := pv.DeepCopy()
+		updatedPV.Labels = volInfo.PVInfo.Labels
+		updatedPV.Spec.PersistentVolumeReclaimPolicy = v1.PersistentVolumeReclaimPolicy(volInfo.PVInfo.ReclaimPolicy)
+
+		filteredAnnotations := filterDeniedAnnotations(volInfo.PVInfo.Annotations)
+		if updatedPV.Annotations == nil && len(filteredAnnotations) > 0 {
+			updatedPV.Annotations = make(map[string]string, len(filteredAnnotations))
+		}
+		for k, v := range filteredAnnotations {
+			updatedPV.Annotations[k] = v
+		}
+
+		updatedPV.Spec.MountOptions = volInfo.PVInfo.MountOptions
+
+		// Only restore the backed-up node affinity if the dynamic provisioner hasn't already
+		// set its own. Dynamic provisioning routinely lands the replacement PV in a different
+		// zone/node than the one that was backed up, so blindly stamping the old topology here
+		// would make the PV permanently unschedulable instead of just losing the field.
+		if pv.Spec.NodeAffinity == nil {
+			updatedPV.Spec.NodeAffinity = volInfo.PVInfo.NodeAffinity
+		}
+
+		if err := kubeutil.PatchResource(pv, updatedPV, ctx.crClient); err != nil {
+			return false, err
+		}
+		log.Infof("newly dynamically provisioned PV:%s has been patched using volume info", pvName)
+	}
+
+	return true, nil
+}
+
+// restoredNamespaceFromPVCKey extracts the namespace portion of a "namespace/name" PVC key, as
+// produced by getRestoredPVCFromRestoredResourceList, so give-up errors can be bucketed the same
+// way as the per-PVC patch errors added while finalization was still in progress.
+func restoredNamespaceFromPVCKey(pvcKey string) string {
+	if idx := strings.Index(pvcKey, "/"); idx >= 0 {
+		return pvcKey[:idx]
+	}
+	return pvcKey
 }
 
 func getRestoredPVCFromRestoredResourceList(restoredResourceList map[string][]string) map[string]struct{} {
@@ -367,7 +642,7 @@ func getRestoredPVCFromRestoredResourceList(restoredResourceList map[string][]st
 		// extract the substring before "(created)" if the status in rightmost Parenthesis is "created"
 		r := regexp.MustCompile(`\(([^)]+)\)`)
 		matches := r.FindAllStringSubmatch(pvc, -1)
-		if len(matches) > 0 && matches[len(matches)-1][1] == restore.ItemRestoreResultCreated {
+		if len(matches) > 0 && matches[len(matches)-1][1] == restorepkg.ItemRestoreResultCreated {
 			pvcList[pvc[:len(pvc)-len("(created)")]] = struct{}{}
 		}
 	}
@@ -390,5 +665,22 @@ func needPatch(newPV *v1.PersistentVolume, pvInfo *internalVolume.PVInfo) bool {
 		}
 	}
 
+	newPVAnnotations := newPV.Annotations
+	for k, v := range filterDeniedAnnotations(pvInfo.Annotations) {
+		if newPVAnnotations[k] != v {
+			return true
+		}
+	}
+
+	if !reflect.DeepEqual(newPV.Spec.MountOptions, pvInfo.MountOptions) {
+		return true
+	}
+
+	// The backed-up node affinity is only ever applied when the new PV doesn't already have its
+	// own (see tryPatchPV), so that's the only case that should trigger a patch here too.
+	if newPV.Spec.NodeAffinity == nil && pvInfo.NodeAffinity != nil {
+		return true
+	}
+
 	return false
 }
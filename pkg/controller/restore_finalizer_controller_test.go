@@ -0,0 +1,539 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clocktesting "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	internalVolume "github.com/vmware-tanzu/velero/internal/volume"
+	velerov1api "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+	"github.com/vmware-tanzu/velero/pkg/metrics"
+	"github.com/vmware-tanzu/velero/pkg/plugin/velero"
+	restorepkg "github.com/vmware-tanzu/velero/pkg/restore"
+	"github.com/vmware-tanzu/velero/pkg/util/results"
+)
+
+// fakeBackupStore is a hand-written test double for restoreFinalizerBackupStore - the narrow
+// slice of persistence.BackupStore the restore finalizer reconciler actually calls - rather than
+// a generated mock of the full persistence.BackupStore interface, which has many methods this
+// reconciler never touches.
+type fakeBackupStore struct {
+	mock.Mock
+}
+
+func (m *fakeBackupStore) GetBackupVolumeInfos(backupName string) ([]*internalVolume.VolumeInfo, error) {
+	args := m.Called(backupName)
+	var r0 []*internalVolume.VolumeInfo
+	if args.Get(0) != nil {
+		r0 = args.Get(0).([]*internalVolume.VolumeInfo)
+	}
+	return r0, args.Error(1)
+}
+
+func (m *fakeBackupStore) GetRestoredResourceList(restoreName string) (map[string][]string, error) {
+	args := m.Called(restoreName)
+	var r0 map[string][]string
+	if args.Get(0) != nil {
+		r0 = args.Get(0).(map[string][]string)
+	}
+	return r0, args.Error(1)
+}
+
+func (m *fakeBackupStore) GetRestoreFinalizerProgress(restoreName string) (restorepkg.FinalizerProgress, error) {
+	args := m.Called(restoreName)
+	if args.Get(0) == nil {
+		return restorepkg.FinalizerProgress{}, args.Error(1)
+	}
+	return args.Get(0).(restorepkg.FinalizerProgress), args.Error(1)
+}
+
+func (m *fakeBackupStore) PutRestoreFinalizerProgress(restoreName string, progress restorepkg.FinalizerProgress) error {
+	args := m.Called(restoreName, progress)
+	return args.Error(0)
+}
+
+func (m *fakeBackupStore) GetRestoreResults(restoreName string) (map[string]results.Result, error) {
+	args := m.Called(restoreName)
+	var r0 map[string]results.Result
+	if args.Get(0) != nil {
+		r0 = args.Get(0).(map[string]results.Result)
+	}
+	return r0, args.Error(1)
+}
+
+func (m *fakeBackupStore) PutRestoreResults(restoreName string, restoreResults map[string]results.Result) error {
+	args := m.Called(restoreName, restoreResults)
+	return args.Error(0)
+}
+
+// fakePluginManager is a hand-written test double for restoreFinalizerPluginManager.
+type fakePluginManager struct {
+	mock.Mock
+}
+
+func (m *fakePluginManager) GetRestoreFinalizerActions() ([]velero.RestoreFinalizerAction, error) {
+	args := m.Called()
+	var r0 []velero.RestoreFinalizerAction
+	if args.Get(0) != nil {
+		r0 = args.Get(0).([]velero.RestoreFinalizerAction)
+	}
+	return r0, args.Error(1)
+}
+
+func newFinalizerTestScheme(t *testing.T) *runtime.Scheme {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, velerov1api.AddToScheme(scheme))
+	return scheme
+}
+
+func newTestRestore(name string) *velerov1api.Restore {
+	return &velerov1api.Restore{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "velero", Name: name},
+		Spec:       velerov1api.RestoreSpec{BackupName: "backup-1"},
+		Status:     velerov1api.RestoreStatus{Phase: velerov1api.RestorePhaseFinalizing},
+	}
+}
+
+func newTestVolumeInfo(pvcName, pvcNamespace string) []*internalVolume.VolumeInfo {
+	return []*internalVolume.VolumeInfo{
+		{
+			BackupMethod: internalVolume.CSISnapshot,
+			PVCName:      pvcName,
+			PVCNamespace: pvcNamespace,
+			PVName:       pvcName + "-pv",
+			PVInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				Labels:        map[string]string{"app": "demo"},
+			},
+		},
+	}
+}
+
+// newFinalizerTestReconciler builds a restoreFinalizerReconciler wired to a fake
+// controller-runtime client (pre-loaded with objs), bypassing Reconcile's backup/storage-location
+// lookup so reconcileFinalization can be exercised directly against a given backup store/plugin
+// manager.
+func newFinalizerTestReconciler(t *testing.T, clock *clocktesting.FakeClock, objs ...client.Object) *restoreFinalizerReconciler {
+	t.Helper()
+	crClient := fake.NewClientBuilder().WithScheme(newFinalizerTestScheme(t)).WithObjects(objs...).Build()
+
+	return &restoreFinalizerReconciler{
+		Client:                      crClient,
+		logger:                      logrus.StandardLogger(),
+		namespace:                   "velero",
+		metrics:                     metrics.NewServerMetrics(),
+		clock:                       clock,
+		crClient:                    crClient,
+		restoreFinalizerConcurrency: 2,
+		pvPatchTimeout:              time.Hour,
+	}
+}
+
+func TestReconcileFinalization_RequeuesWhenPVCsPending(t *testing.T) {
+	restore := newTestRestore("restore-1")
+	volumeInfo := newTestVolumeInfo("pvc-1", "velero")
+
+	// No matching PVC exists in the cluster yet, so patching can't proceed this pass.
+	r := newFinalizerTestReconciler(t, clocktesting.NewFakeClock(time.Now()))
+
+	backupStore := new(fakeBackupStore)
+	backupStore.On("GetBackupVolumeInfos", "backup-1").Return(volumeInfo, nil)
+	backupStore.On("GetRestoredResourceList", "restore-1").Return(map[string][]string{
+		"v1/PersistentVolumeClaim": {"velero/pvc-1(created)"},
+	}, nil)
+	// No checkpoint exists yet - this is the first reconcile for this restore.
+	backupStore.On("GetRestoreFinalizerProgress", "restore-1").Return(restorepkg.FinalizerProgress{}, restorepkg.ErrFinalizerProgressNotFound)
+	backupStore.On("PutRestoreFinalizerProgress", "restore-1", mock.Anything).Return(nil)
+
+	pluginManager := new(fakePluginManager)
+
+	result, err := r.reconcileFinalization(r.logger, restore, restore.DeepCopy(), backupStore, pluginManager)
+	require.NoError(t, err)
+	assert.Greater(t, result.RequeueAfter, time.Duration(0))
+	assert.Equal(t, velerov1api.RestorePhaseFinalizing, restore.Status.Phase, "phase should not be finalized while a PVC is still pending")
+
+	backupStore.AssertCalled(t, "PutRestoreFinalizerProgress", "restore-1", mock.MatchedBy(func(p restorepkg.FinalizerProgress) bool {
+		return p.Attempts == 1
+	}))
+}
+
+func TestReconcileFinalization_ResumesFromCheckpoint(t *testing.T) {
+	restore := newTestRestore("restore-1")
+	volumeInfo := append(newTestVolumeInfo("pvc-1", "velero"), newTestVolumeInfo("pvc-2", "velero")...)
+
+	boundPV1 := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1-pv"},
+		Spec: v1.PersistentVolumeSpec{
+			ClaimRef: &v1.ObjectReference{Name: "pvc-1", Namespace: "velero"},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+	boundPVC1 := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "velero"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pvc-1-pv"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+	boundPV2 := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-2-pv"},
+		Spec: v1.PersistentVolumeSpec{
+			ClaimRef: &v1.ObjectReference{Name: "pvc-2", Namespace: "velero"},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+	boundPVC2 := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-2", Namespace: "velero"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pvc-2-pv"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+
+	// the restore itself is loaded too, since finishing finalization patches its status.
+	r := newFinalizerTestReconciler(t, clocktesting.NewFakeClock(time.Now()), restore, boundPV1, boundPVC1, boundPV2, boundPVC2)
+
+	backupStore := new(fakeBackupStore)
+	backupStore.On("GetBackupVolumeInfos", "backup-1").Return(volumeInfo, nil)
+	backupStore.On("GetRestoredResourceList", "restore-1").Return(map[string][]string{
+		"v1/PersistentVolumeClaim": {"velero/pvc-1(created)", "velero/pvc-2(created)"},
+	}, nil)
+	// pvc-1 was already patched by a previous reconcile; only pvc-2 is new work this pass.
+	backupStore.On("GetRestoreFinalizerProgress", "restore-1").Return(restorepkg.FinalizerProgress{
+		PatchedPVCs:  map[string]bool{"velero/pvc-1": true},
+		Attempts:     1,
+		FirstAttempt: time.Now().Add(-time.Minute),
+	}, nil)
+	backupStore.On("GetRestoreResults", "restore-1").Return(map[string]results.Result{}, nil)
+
+	pluginManager := new(fakePluginManager)
+	pluginManager.On("GetRestoreFinalizerActions").Return(nil, nil)
+
+	original := restore.DeepCopy()
+	result, err := r.reconcileFinalization(r.logger, restore, original, backupStore, pluginManager)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), result.RequeueAfter, "no PVCs should remain pending")
+	assert.Equal(t, velerov1api.RestorePhaseCompleted, restore.Status.Phase)
+
+	// pvc-1 was already checkpointed as patched, so it must not be re-patched this pass.
+	patchedPV1 := &v1.PersistentVolume{}
+	require.NoError(t, r.crClient.Get(context.Background(), client.ObjectKey{Name: "pvc-1-pv"}, patchedPV1))
+	assert.Nil(t, patchedPV1.Labels, "already-checkpointed PVC should be skipped, not re-patched")
+
+	patchedPV2 := &v1.PersistentVolume{}
+	require.NoError(t, r.crClient.Get(context.Background(), client.ObjectKey{Name: "pvc-2-pv"}, patchedPV2))
+	assert.Equal(t, map[string]string{"app": "demo"}, patchedPV2.Labels, "newly-bound PVC should be patched this pass")
+
+	backupStore.AssertNotCalled(t, "PutRestoreFinalizerProgress", mock.Anything, mock.Anything)
+}
+
+func TestReconcileFinalization_RetryBudgetExceeded(t *testing.T) {
+	restore := newTestRestore("restore-1")
+	volumeInfo := newTestVolumeInfo("pvc-1", "velero")
+
+	// No PVC ever shows up in the cluster - this PVC will never bind. Only the restore is loaded,
+	// since finishing finalization patches its status.
+	r := newFinalizerTestReconciler(t, clocktesting.NewFakeClock(time.Now()), restore)
+
+	backupStore := new(fakeBackupStore)
+	backupStore.On("GetBackupVolumeInfos", "backup-1").Return(volumeInfo, nil)
+	backupStore.On("GetRestoredResourceList", "restore-1").Return(map[string][]string{
+		"v1/PersistentVolumeClaim": {"velero/pvc-1(created)"},
+	}, nil)
+	backupStore.On("GetRestoreFinalizerProgress", "restore-1").Return(restorepkg.FinalizerProgress{
+		PatchedPVCs:  map[string]bool{},
+		Attempts:     DefaultMaxFinalizerRetries - 1,
+		FirstAttempt: time.Now().Add(-time.Hour),
+	}, nil)
+	backupStore.On("GetRestoreResults", "restore-1").Return(map[string]results.Result{}, nil)
+	backupStore.On("PutRestoreResults", "restore-1", mock.Anything).Return(nil)
+
+	pluginManager := new(fakePluginManager)
+	pluginManager.On("GetRestoreFinalizerActions").Return(nil, nil)
+
+	original := restore.DeepCopy()
+	result, err := r.reconcileFinalization(r.logger, restore, original, backupStore, pluginManager)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), result.RequeueAfter, "retry budget is exhausted, so this should finalize instead of requeuing")
+	assert.Equal(t, velerov1api.RestorePhasePartiallyFailed, restore.Status.Phase)
+
+	// the give-up error for the PVC that never bound should land under its real namespace,
+	// not the catch-all bucket (see restoredNamespaceFromPVCKey).
+	backupStore.AssertCalled(t, "PutRestoreResults", "restore-1", mock.MatchedBy(func(res map[string]results.Result) bool {
+		errsResult, ok := res["errors"]
+		if !ok {
+			return false
+		}
+		_, hasNamespacedError := errsResult.Namespaces["velero"]
+		return hasNamespacedError
+	}))
+}
+
+func TestReconcileFinalization_PropagatesCheckpointReadError(t *testing.T) {
+	restore := newTestRestore("restore-1")
+	volumeInfo := newTestVolumeInfo("pvc-1", "velero")
+
+	r := newFinalizerTestReconciler(t, clocktesting.NewFakeClock(time.Now()))
+
+	backupStore := new(fakeBackupStore)
+	backupStore.On("GetBackupVolumeInfos", "backup-1").Return(volumeInfo, nil)
+	backupStore.On("GetRestoredResourceList", "restore-1").Return(map[string][]string{
+		"v1/PersistentVolumeClaim": {"velero/pvc-1(created)"},
+	}, nil)
+	// A transient read error, distinct from ErrFinalizerProgressNotFound, must not be treated as
+	// "no checkpoint yet" - doing so would reset Attempts/FirstAttempt on every reconcile and the
+	// retry budget could never be reached.
+	backupStore.On("GetRestoreFinalizerProgress", "restore-1").Return(restorepkg.FinalizerProgress{}, assert.AnError)
+
+	pluginManager := new(fakePluginManager)
+
+	result, err := r.reconcileFinalization(r.logger, restore, restore.DeepCopy(), backupStore, pluginManager)
+	assert.Error(t, err)
+	assert.Equal(t, ctrl.Result{}, result)
+
+	backupStore.AssertNotCalled(t, "PutRestoreFinalizerProgress", mock.Anything, mock.Anything)
+}
+
+func TestNeedPatch(t *testing.T) {
+	affinityA := &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{
+				{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east-1a"}}}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name   string
+		newPV  *v1.PersistentVolume
+		pvInfo *internalVolume.PVInfo
+		want   bool
+	}{
+		{
+			name:  "reclaim policy differs",
+			newPV: &v1.PersistentVolume{Spec: v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete}},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimRetain),
+			},
+			want: true,
+		},
+		{
+			name: "label missing from new PV",
+			newPV: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}},
+				Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				Labels:        map[string]string{"app": "demo"},
+			},
+			want: true,
+		},
+		{
+			name: "denied annotation present on backup info is ignored",
+			newPV: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				Annotations:   map[string]string{"pv.kubernetes.io/provisioned-by": "csi.example.com"},
+			},
+			want: false,
+		},
+		{
+			name: "non-denied annotation missing from new PV",
+			newPV: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				Annotations:   map[string]string{"example.com/owner": "team-a"},
+			},
+			want: true,
+		},
+		{
+			name: "mount options differ",
+			newPV: &v1.PersistentVolume{
+				Spec: v1.PersistentVolumeSpec{
+					PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+					MountOptions:                  []string{"ro"},
+				},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				MountOptions:  []string{"ro", "noatime"},
+			},
+			want: true,
+		},
+		{
+			name: "new PV already has its own node affinity",
+			newPV: &v1.PersistentVolume{
+				Spec: v1.PersistentVolumeSpec{
+					PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete,
+					NodeAffinity:                  affinityA,
+				},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				NodeAffinity:  affinityA,
+			},
+			want: false,
+		},
+		{
+			name: "new PV has no node affinity but backup info does",
+			newPV: &v1.PersistentVolume{
+				Spec: v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				NodeAffinity:  affinityA,
+			},
+			want: true,
+		},
+		{
+			name: "nothing to patch",
+			newPV: &v1.PersistentVolume{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "demo"}},
+				Spec:       v1.PersistentVolumeSpec{PersistentVolumeReclaimPolicy: v1.PersistentVolumeReclaimDelete},
+			},
+			pvInfo: &internalVolume.PVInfo{
+				ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+				Labels:        map[string]string{"app": "demo"},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, needPatch(tc.newPV, tc.pvInfo))
+		})
+	}
+}
+
+func TestTryPatchPV_AnnotationsMergeAndDenyList(t *testing.T) {
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "velero"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pvc-1-pv"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "pvc-1-pv",
+			Annotations: map[string]string{"existing.example.com/owner": "team-a"},
+		},
+		Spec: v1.PersistentVolumeSpec{
+			ClaimRef: &v1.ObjectReference{Name: "pvc-1", Namespace: "velero"},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+
+	crClient := fake.NewClientBuilder().WithScheme(newFinalizerTestScheme(t)).WithObjects(pvc, pv).Build()
+	ctx := &finalizerContext{logger: logrus.StandardLogger(), crClient: crClient}
+
+	volInfo := internalVolume.VolumeInfo{
+		PVCName: "pvc-1",
+		PVName:  "pvc-1-pv",
+		PVInfo: &internalVolume.PVInfo{
+			ReclaimPolicy: string(v1.PersistentVolumeReclaimDelete),
+			Annotations: map[string]string{
+				"pv.kubernetes.io/provisioned-by": "csi.example.com", // deny-listed, must not be copied
+				"backup.example.com/source":       "nightly",
+			},
+		},
+	}
+
+	bound, err := ctx.tryPatchPV(volInfo, "velero")
+	require.NoError(t, err)
+	assert.True(t, bound)
+
+	patched := &v1.PersistentVolume{}
+	require.NoError(t, crClient.Get(context.Background(), client.ObjectKey{Name: "pvc-1-pv"}, patched))
+	assert.Equal(t, "team-a", patched.Annotations["existing.example.com/owner"], "annotations not present in volume info should be preserved")
+	assert.Equal(t, "nightly", patched.Annotations["backup.example.com/source"], "non-denied annotations from volume info should be merged in")
+	_, hasDeniedAnnotation := patched.Annotations["pv.kubernetes.io/provisioned-by"]
+	assert.False(t, hasDeniedAnnotation, "deny-listed annotations must never be copied onto the PV")
+}
+
+func TestTryPatchPV_SkipsNodeAffinityWhenAlreadySet(t *testing.T) {
+	existingAffinity := &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{
+				{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east-1b"}}}},
+			},
+		},
+	}
+	backedUpAffinity := &v1.VolumeNodeAffinity{
+		Required: &v1.NodeSelector{
+			NodeSelectorTerms: []v1.NodeSelectorTerm{
+				{MatchExpressions: []v1.NodeSelectorRequirement{{Key: "topology.kubernetes.io/zone", Operator: v1.NodeSelectorOpIn, Values: []string{"us-east-1a"}}}},
+			},
+		},
+	}
+
+	pvc := &v1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1", Namespace: "velero"},
+		Spec:       v1.PersistentVolumeClaimSpec{VolumeName: "pvc-1-pv"},
+		Status:     v1.PersistentVolumeClaimStatus{Phase: v1.ClaimBound},
+	}
+	pv := &v1.PersistentVolume{
+		ObjectMeta: metav1.ObjectMeta{Name: "pvc-1-pv"},
+		Spec: v1.PersistentVolumeSpec{
+			ClaimRef:     &v1.ObjectReference{Name: "pvc-1", Namespace: "velero"},
+			NodeAffinity: existingAffinity,
+			// force needPatch to trip on something other than node affinity so tryPatchPV still
+			// goes through the patch path for this case.
+			MountOptions: []string{"ro"},
+		},
+		Status: v1.PersistentVolumeStatus{Phase: v1.VolumeBound},
+	}
+
+	crClient := fake.NewClientBuilder().WithScheme(newFinalizerTestScheme(t)).WithObjects(pvc, pv).Build()
+	ctx := &finalizerContext{logger: logrus.StandardLogger(), crClient: crClient}
+
+	volInfo := internalVolume.VolumeInfo{
+		PVCName: "pvc-1",
+		PVName:  "pvc-1-pv",
+		PVInfo: &internalVolume.PVInfo{
+			ReclaimPolicy: "",
+			NodeAffinity:  backedUpAffinity,
+		},
+	}
+
+	bound, err := ctx.tryPatchPV(volInfo, "velero")
+	require.NoError(t, err)
+	assert.True(t, bound)
+
+	patched := &v1.PersistentVolume{}
+	require.NoError(t, crClient.Get(context.Background(), client.ObjectKey{Name: "pvc-1-pv"}, patched))
+	assert.Equal(t, existingAffinity, patched.Spec.NodeAffinity, "a dynamically-provisioned PV's own node affinity must never be overwritten with backed-up topology")
+}
@@ -0,0 +1,66 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+const (
+	// RestoreFinalizerConcurrencyFlag is the Velero server flag controlling how many PVs the
+	// restore finalizer reconciler patches concurrently.
+	RestoreFinalizerConcurrencyFlag = "restore-finalizer-concurrency"
+
+	// PVPatchTimeoutFlag is the Velero server flag controlling how long the restore finalizer
+	// reconciler retries a restore's PV patching, across all attempts, before giving up.
+	PVPatchTimeoutFlag = "pv-patch-timeout"
+)
+
+// RestoreFinalizerReconcilerConfig holds the restore finalizer reconciler's server-configurable
+// settings. BindFlags registers them as command-line flags. NewRestoreFinalizerReconciler takes
+// the two values as separate arguments; nothing in this package passes a
+// RestoreFinalizerReconcilerConfig to it.
+type RestoreFinalizerReconcilerConfig struct {
+	// RestoreFinalizerConcurrency is the number of PVs patched concurrently per finalization
+	// reconcile. Defaults to DefaultRestoreFinalizerConcurrency.
+	RestoreFinalizerConcurrency int
+
+	// PVPatchTimeout is how long, across all retries, the reconciler keeps trying to patch a
+	// restore's PVs before giving up and marking it PartiallyFailed. Defaults to
+	// PVPatchMaximumDuration.
+	PVPatchTimeout time.Duration
+}
+
+// NewRestoreFinalizerReconcilerConfig returns a RestoreFinalizerReconcilerConfig populated with
+// the restore finalizer reconciler's defaults.
+func NewRestoreFinalizerReconcilerConfig() RestoreFinalizerReconcilerConfig {
+	return RestoreFinalizerReconcilerConfig{
+		RestoreFinalizerConcurrency: DefaultRestoreFinalizerConcurrency,
+		PVPatchTimeout:              PVPatchMaximumDuration,
+	}
+}
+
+// BindFlags registers the restore finalizer reconciler's flags on the given flag set, using c's
+// current values as the flag defaults and writing parsed values back into c.
+func (c *RestoreFinalizerReconcilerConfig) BindFlags(flags *pflag.FlagSet) {
+	flags.IntVar(&c.RestoreFinalizerConcurrency, RestoreFinalizerConcurrencyFlag, c.RestoreFinalizerConcurrency,
+		"how many PVs the restore finalizer reconciler patches concurrently")
+	flags.DurationVar(&c.PVPatchTimeout, PVPatchTimeoutFlag, c.PVPatchTimeout,
+		"how long, across all retries, the restore finalizer reconciler waits for a restore's PVs to be patched before giving up")
+}
@@ -0,0 +1,46 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/pflag"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreFinalizerReconcilerConfig_BindFlags(t *testing.T) {
+	c := NewRestoreFinalizerReconcilerConfig()
+	assert.Equal(t, DefaultRestoreFinalizerConcurrency, c.RestoreFinalizerConcurrency)
+	assert.Equal(t, PVPatchMaximumDuration, c.PVPatchTimeout)
+
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	c.BindFlags(flags)
+
+	require := []string{RestoreFinalizerConcurrencyFlag, PVPatchTimeoutFlag}
+	for _, name := range require {
+		assert.NotNil(t, flags.Lookup(name), "expected flag %q to be registered", name)
+	}
+
+	assert.NoError(t, flags.Parse([]string{
+		"--" + RestoreFinalizerConcurrencyFlag, "7",
+		"--" + PVPatchTimeoutFlag, "5m",
+	}))
+	assert.Equal(t, 7, c.RestoreFinalizerConcurrency)
+	assert.Equal(t, 5*time.Minute, c.PVPatchTimeout)
+}
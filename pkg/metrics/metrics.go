@@ -0,0 +1,131 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package metrics exposes the Prometheus metrics the Velero server records about its own
+// operation, as opposed to metrics about the workloads it protects.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+const (
+	metricNamespace = "velero"
+
+	restoreSuccessTotal        = "restore_success_total"
+	restorePartialFailureTotal = "restore_partial_failure_total"
+	restoreFinalizationSeconds = "restore_finalization_duration_seconds"
+	pvPatchTotal               = "restore_pv_patch_total"
+
+	scheduleLabel  = "schedule"
+	namespaceLabel = "namespace"
+	outcomeLabel   = "outcome"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+)
+
+// ServerMetrics holds the Prometheus collectors for metrics the Velero server records about its
+// own operation. Call NewServerMetrics to construct one, then RegisterAllMetrics to make its
+// collectors visible to a Prometheus registry.
+type ServerMetrics struct {
+	metrics map[string]prometheus.Collector
+}
+
+// NewServerMetrics creates a ServerMetrics with all of the server's collectors initialized but
+// not yet registered with any Prometheus registry.
+func NewServerMetrics() *ServerMetrics {
+	return &ServerMetrics{
+		metrics: map[string]prometheus.Collector{
+			restoreSuccessTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      restoreSuccessTotal,
+					Help:      "Total number of successful restores",
+				},
+				[]string{scheduleLabel},
+			),
+			restorePartialFailureTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      restorePartialFailureTotal,
+					Help:      "Total number of partially failed restores",
+				},
+				[]string{scheduleLabel},
+			),
+			restoreFinalizationSeconds: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: metricNamespace,
+					Name:      restoreFinalizationSeconds,
+					Help:      "Time, in seconds, that a restore spent in the finalizing phase, measured across its full checkpoint/retry loop",
+					Buckets:   prometheus.ExponentialBuckets(1, 2, 10),
+				},
+				[]string{scheduleLabel},
+			),
+			pvPatchTotal: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: metricNamespace,
+					Name:      pvPatchTotal,
+					Help:      "Total number of restore finalizer PV patch attempts, by restored namespace and outcome",
+				},
+				[]string{namespaceLabel, outcomeLabel},
+			),
+		},
+	}
+}
+
+// RegisterAllMetrics registers all of the server's collectors with the given Prometheus
+// registerer.
+func (m *ServerMetrics) RegisterAllMetrics(reg prometheus.Registerer) {
+	for _, collector := range m.metrics {
+		reg.MustRegister(collector)
+	}
+}
+
+// RegisterRestoreSuccess records a successful restore for the given schedule. scheduleName is
+// empty for restores not created from a schedule.
+func (m *ServerMetrics) RegisterRestoreSuccess(scheduleName string) {
+	if c, ok := m.metrics[restoreSuccessTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(scheduleName).Inc()
+	}
+}
+
+// RegisterRestorePartialFailure records a partially-failed restore for the given schedule.
+// scheduleName is empty for restores not created from a schedule.
+func (m *ServerMetrics) RegisterRestorePartialFailure(scheduleName string) {
+	if c, ok := m.metrics[restorePartialFailureTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(scheduleName).Inc()
+	}
+}
+
+// ObserveRestoreFinalizationDuration records how long, in seconds, a restore spent in the
+// finalizing phase across its full checkpoint/retry loop, from the first finalization attempt
+// until it was either completed or given up on.
+func (m *ServerMetrics) ObserveRestoreFinalizationDuration(scheduleName string, seconds float64) {
+	if h, ok := m.metrics[restoreFinalizationSeconds].(*prometheus.HistogramVec); ok {
+		h.WithLabelValues(scheduleName).Observe(seconds)
+	}
+}
+
+// RegisterPVPatchOutcome records the outcome of a single restore finalizer PV patch attempt,
+// bucketed by the namespace the PVC was restored into.
+func (m *ServerMetrics) RegisterPVPatchOutcome(namespace string, success bool) {
+	outcome := outcomeFailure
+	if success {
+		outcome = outcomeSuccess
+	}
+	if c, ok := m.metrics[pvPatchTotal].(*prometheus.CounterVec); ok {
+		c.WithLabelValues(namespace, outcome).Inc()
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package clientmgmt manages the lifecycle of Velero plugin processes and exposes their plugins
+// to the rest of the server as in-process-looking Go values, regardless of plugin kind.
+package clientmgmt
+
+import (
+	"github.com/sirupsen/logrus"
+
+	"github.com/vmware-tanzu/velero/pkg/plugin/framework"
+	veleroplugin "github.com/vmware-tanzu/velero/pkg/plugin/velero"
+)
+
+// Manager gets plugin implementations from the Velero server's plugin binaries. It declares a
+// single method, GetRestoreFinalizerActions; it has no getters for other plugin kinds (backup
+// item actions, restore item actions, object stores, volume snapshotters, ...).
+type Manager interface {
+	// GetRestoreFinalizerActions returns all registered RestoreFinalizerAction plugins, in the
+	// order they should be executed.
+	GetRestoreFinalizerActions() ([]veleroplugin.RestoreFinalizerAction, error)
+}
+
+// manager is the concrete Manager implementation used by the Velero server. It resolves
+// plugins by kind through a registry that locates the plugin binary providing each named
+// implementation and a process factory that starts (or reuses) the binary's process.
+type manager struct {
+	log            logrus.FieldLogger
+	registry       Registry
+	processFactory RestartableProcessFactory
+}
+
+// Registry locates which plugin binary provides a given (kind, name) plugin implementation.
+type Registry interface {
+	List(kind string) []PluginIdentifier
+}
+
+// PluginIdentifier identifies a single plugin implementation served by a plugin binary.
+type PluginIdentifier struct {
+	Kind    string
+	Name    string
+	Command string
+}
+
+// RestartableProcess is a plugin binary process that can be asked for one of the plugin
+// implementations it serves, and that Velero restarts transparently if it dies mid-use.
+type RestartableProcess interface {
+	GetRestoreFinalizerAction(name string) (veleroplugin.RestoreFinalizerAction, error)
+}
+
+// RestartableProcessFactory gets (starting it if necessary) the RestartableProcess for a plugin
+// binary command.
+type RestartableProcessFactory interface {
+	GetRestartableProcess(command string) (RestartableProcess, error)
+}
+
+// NewManager creates a Manager that resolves plugins via registry and processFactory.
+func NewManager(log logrus.FieldLogger, registry Registry, processFactory RestartableProcessFactory) Manager {
+	return &manager{
+		log:            log,
+		registry:       registry,
+		processFactory: processFactory,
+	}
+}
+
+func (m *manager) GetRestoreFinalizerActions() ([]veleroplugin.RestoreFinalizerAction, error) {
+	list := m.registry.List(framework.PluginKindRestoreFinalizerAction)
+
+	actions := make([]veleroplugin.RestoreFinalizerAction, 0, len(list))
+	for _, id := range list {
+		process, err := m.processFactory.GetRestartableProcess(id.Command)
+		if err != nil {
+			return nil, err
+		}
+
+		action, err := process.GetRestoreFinalizerAction(id.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clientmgmt
+
+import (
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/velero/pkg/plugin/framework"
+	veleroplugin "github.com/vmware-tanzu/velero/pkg/plugin/velero"
+)
+
+type fakeRegistry struct {
+	mock.Mock
+}
+
+func (m *fakeRegistry) List(kind string) []PluginIdentifier {
+	args := m.Called(kind)
+	if args.Get(0) == nil {
+		return nil
+	}
+	return args.Get(0).([]PluginIdentifier)
+}
+
+type fakeProcessFactory struct {
+	mock.Mock
+}
+
+func (m *fakeProcessFactory) GetRestartableProcess(command string) (RestartableProcess, error) {
+	args := m.Called(command)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(RestartableProcess), args.Error(1)
+}
+
+type fakeRestartableProcess struct {
+	mock.Mock
+}
+
+func (m *fakeRestartableProcess) GetRestoreFinalizerAction(name string) (veleroplugin.RestoreFinalizerAction, error) {
+	args := m.Called(name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(veleroplugin.RestoreFinalizerAction), args.Error(1)
+}
+
+type fakeRestoreFinalizerAction struct {
+	name string
+}
+
+func (f *fakeRestoreFinalizerAction) AppliesTo() (veleroplugin.ResourceSelector, error) {
+	return veleroplugin.ResourceSelector{}, nil
+}
+
+func (f *fakeRestoreFinalizerAction) Execute(*veleroplugin.RestoreFinalizerActionExecuteInput) (veleroplugin.RestoreFinalizerActionExecuteOutput, error) {
+	return veleroplugin.RestoreFinalizerActionExecuteOutput{}, nil
+}
+
+func (f *fakeRestoreFinalizerAction) Name() string {
+	return f.name
+}
+
+func TestManager_GetRestoreFinalizerActions(t *testing.T) {
+	registry := new(fakeRegistry)
+	registry.On("List", framework.PluginKindRestoreFinalizerAction).Return([]PluginIdentifier{
+		{Kind: framework.PluginKindRestoreFinalizerAction, Name: "example.io/my-action", Command: "/plugins/example"},
+	})
+
+	action := &fakeRestoreFinalizerAction{name: "example.io/my-action"}
+	process := new(fakeRestartableProcess)
+	process.On("GetRestoreFinalizerAction", "example.io/my-action").Return(action, nil)
+
+	processFactory := new(fakeProcessFactory)
+	processFactory.On("GetRestartableProcess", "/plugins/example").Return(process, nil)
+
+	m := NewManager(logrus.StandardLogger(), registry, processFactory)
+
+	actions, err := m.GetRestoreFinalizerActions()
+	require.NoError(t, err)
+	require.Len(t, actions, 1)
+	assert.Equal(t, "example.io/my-action", actions[0].Name())
+}
@@ -0,0 +1,114 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package framework wires Velero's plugin kinds to hashicorp/go-plugin so that each kind's
+// interface can be implemented by an out-of-process plugin binary and called from the server as
+// if it were an in-process Go value.
+package framework
+
+import (
+	"net/rpc"
+
+	plugin "github.com/hashicorp/go-plugin"
+
+	veleroplugin "github.com/vmware-tanzu/velero/pkg/plugin/velero"
+)
+
+// PluginKindRestoreFinalizerAction is the plugin kind under which a velero.RestoreFinalizerAction
+// is registered and served, alongside Velero's other plugin kinds (restore item action, backup
+// item action, object store, volume snapshotter, ...).
+const PluginKindRestoreFinalizerAction = "RestoreFinalizerAction"
+
+// RestoreFinalizerActionPlugin implements go-plugin's plugin.Plugin interface for the
+// RestoreFinalizerAction plugin kind, so that a velero.RestoreFinalizerAction can be shipped as
+// an out-of-process plugin binary and invoked by the restore finalizer reconciler over RPC.
+type RestoreFinalizerActionPlugin struct {
+	impl veleroplugin.RestoreFinalizerAction
+}
+
+// NewRestoreFinalizerActionPlugin constructs a RestoreFinalizerActionPlugin serving impl. impl is
+// nil on the client side, where only Client is ever called.
+func NewRestoreFinalizerActionPlugin(impl veleroplugin.RestoreFinalizerAction) *RestoreFinalizerActionPlugin {
+	return &RestoreFinalizerActionPlugin{impl: impl}
+}
+
+// Server returns an RPC server that dispatches to the plugin's RestoreFinalizerAction
+// implementation. It's called in the plugin binary process.
+func (p *RestoreFinalizerActionPlugin) Server(*plugin.MuxBroker) (interface{}, error) {
+	return &RestoreFinalizerActionServer{impl: p.impl}, nil
+}
+
+// Client returns an RPC client that satisfies veleroplugin.RestoreFinalizerAction by calling out
+// to the plugin binary process. It's called in the Velero server process.
+func (p *RestoreFinalizerActionPlugin) Client(_ *plugin.MuxBroker, client *rpc.Client) (interface{}, error) {
+	return &RestoreFinalizerActionClient{client: client}, nil
+}
+
+// RestoreFinalizerActionServer exposes a velero.RestoreFinalizerAction over net/rpc, for use on
+// the plugin binary side of the RestoreFinalizerActionPlugin.
+type RestoreFinalizerActionServer struct {
+	impl veleroplugin.RestoreFinalizerAction
+}
+
+func (s *RestoreFinalizerActionServer) AppliesTo(_ struct{}, reply *veleroplugin.ResourceSelector) error {
+	selector, err := s.impl.AppliesTo()
+	if err != nil {
+		return err
+	}
+	*reply = selector
+	return nil
+}
+
+func (s *RestoreFinalizerActionServer) Execute(input *veleroplugin.RestoreFinalizerActionExecuteInput, reply *veleroplugin.RestoreFinalizerActionExecuteOutput) error {
+	output, err := s.impl.Execute(input)
+	if err != nil {
+		return err
+	}
+	*reply = output
+	return nil
+}
+
+func (s *RestoreFinalizerActionServer) Name(_ struct{}, reply *string) error {
+	*reply = s.impl.Name()
+	return nil
+}
+
+// RestoreFinalizerActionClient implements veleroplugin.RestoreFinalizerAction by calling out to a
+// RestoreFinalizerActionServer over net/rpc, for use on the Velero server side of the
+// RestoreFinalizerActionPlugin.
+type RestoreFinalizerActionClient struct {
+	client *rpc.Client
+}
+
+func (c *RestoreFinalizerActionClient) AppliesTo() (veleroplugin.ResourceSelector, error) {
+	var reply veleroplugin.ResourceSelector
+	err := c.client.Call("Plugin.AppliesTo", struct{}{}, &reply)
+	return reply, err
+}
+
+func (c *RestoreFinalizerActionClient) Execute(input *veleroplugin.RestoreFinalizerActionExecuteInput) (veleroplugin.RestoreFinalizerActionExecuteOutput, error) {
+	var reply veleroplugin.RestoreFinalizerActionExecuteOutput
+	err := c.client.Call("Plugin.Execute", input, &reply)
+	return reply, err
+}
+
+func (c *RestoreFinalizerActionClient) Name() string {
+	var reply string
+	if err := c.client.Call("Plugin.Name", struct{}{}, &reply); err != nil {
+		return ""
+	}
+	return reply
+}
@@ -0,0 +1,65 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package velero
+
+import (
+	internalVolume "github.com/vmware-tanzu/velero/internal/volume"
+	velerov1api "github.com/vmware-tanzu/velero/pkg/apis/velero/v1"
+)
+
+// RestoreFinalizerActionExecuteInput is the input for a RestoreFinalizerAction, run by the
+// restore finalizer reconciler after the built-in finalization steps (e.g. dynamic PV patching)
+// have completed for a restore.
+type RestoreFinalizerActionExecuteInput struct {
+	// Restore is the restore object being finalized.
+	Restore *velerov1api.Restore
+	// VolumeInfo is the volume info recorded for the backup the restore was created from.
+	VolumeInfo []*internalVolume.VolumeInfo
+	// RestoredPVCList is the set of "namespace/name" keys of the PVCs that were
+	// actually (re)created by the restore.
+	RestoredPVCList map[string]struct{}
+}
+
+// RestoreFinalizerActionExecuteOutput is the output of a RestoreFinalizerAction's Execute method.
+type RestoreFinalizerActionExecuteOutput struct {
+	// Warnings is a list of non-fatal messages surfaced by the plugin, merged into the
+	// restore's results and persisted alongside the built-in finalization warnings.
+	Warnings []string
+}
+
+// RestoreFinalizerAction is a plugin that runs, once per restore, after Velero's built-in
+// restore finalization tasks have completed (e.g. after dynamic PVs have been patched with
+// their backed-up volume info). It allows operators to perform workload-specific fix-ups that
+// can only happen once the restored resources exist in the cluster, such as re-annotating PVs
+// for a custom CSI driver, kicking a StatefulSet rollout, or rebinding external DNS.
+type RestoreFinalizerAction interface {
+	// AppliesTo returns a selector describing the resources this action is concerned with.
+	// It is not given a specific item, since the action applies to the restore as a whole
+	// rather than to an individual item. The restore finalizer reconciler does not consult
+	// it: every registered RestoreFinalizerAction is executed once per restore.
+	AppliesTo() (ResourceSelector, error)
+
+	// Execute runs the plugin's finalization logic for the given restore. It returns a
+	// RestoreFinalizerActionExecuteOutput along with any error that occurred. Errors are
+	// merged into the restore's finalization results and may cause the restore to be marked
+	// PartiallyFailed instead of Completed.
+	Execute(input *RestoreFinalizerActionExecuteInput) (RestoreFinalizerActionExecuteOutput, error)
+
+	// Name returns the plugin's name, used in log messages and to identify it among the
+	// set of registered RestoreFinalizerActions.
+	Name() string
+}
@@ -0,0 +1,45 @@
+/*
+Copyright the Velero contributors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package restore
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrFinalizerProgressNotFound is returned by a persistence.BackupStore's
+// GetRestoreFinalizerProgress when the named restore has no finalizer checkpoint yet, e.g.
+// because this is the first time it's being finalized. Callers should treat it as "start from an
+// empty FinalizerProgress" rather than as a failure to read the checkpoint.
+var ErrFinalizerProgressNotFound = errors.New("restore finalizer progress not found")
+
+// FinalizerProgress is a checkpoint of how far the restore finalizer reconciler has gotten in
+// patching dynamically-provisioned PVs with their backed-up volume info. It is written to the
+// backup store as restore-<name>-finalizer-progress.json so that a crash or restart of the
+// reconciler can resume from where it left off instead of starting the whole restore over.
+type FinalizerProgress struct {
+	// PatchedPVCs is the set of "namespace/name" PVC keys whose PV has already been
+	// successfully patched with its backed-up volume info.
+	PatchedPVCs map[string]bool `json:"patchedPVCs"`
+
+	// Attempts is the number of finalization reconciles that have run for this restore.
+	Attempts int `json:"attempts"`
+
+	// FirstAttempt is when finalization of this restore was first attempted, used together
+	// with Attempts to decide when to give up on PVCs that never bind.
+	FirstAttempt time.Time `json:"firstAttempt"`
+}